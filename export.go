@@ -0,0 +1,180 @@
+package treeview
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// ClassProvider is an optional interface a NodeProvider can implement to
+// attach a CSS class to each node in RenderHTML's output, e.g. to style
+// files and folders differently. Providers that don't implement it get
+// nodes with no class attribute.
+type ClassProvider[T any] interface {
+	Class(node *Node[T]) string
+}
+
+// markdownEscaper escapes characters that have special meaning in Markdown,
+// so labels containing them render as literal text instead of formatting.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+)
+
+// RenderMarkdown walks the tree's visible nodes, in the same order and
+// honoring the same expansion/visibility state as renderTree, and emits a
+// nested "- " bullet list indented by two spaces per depth level. For a
+// root holding file.txt and subdir, where subdir holds nested.txt, the
+// output is:
+//
+//	"- 📁 root\n  - 📄 file.txt\n  - 📁 subdir\n    - 📄 nested.txt\n"
+//
+// Each bullet's text comes from the provider's Format, prefixed with Icon
+// when it returns one. Special Markdown characters in labels are escaped.
+func RenderMarkdown[T any](ctx context.Context, tree *Tree[T]) (string, error) {
+	var sb strings.Builder
+
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return sb.String(), err
+		}
+		node := info.Node
+
+		sb.WriteString(strings.Repeat("  ", info.Depth))
+		sb.WriteString("- ")
+		if icon := strings.TrimRight(tree.provider.Icon(node), " "); icon != "" {
+			sb.WriteString(icon)
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(markdownEscaper.Replace(tree.provider.Format(node)))
+		sb.WriteByte('\n')
+
+		if err := ctx.Err(); err != nil {
+			return sb.String(), err
+		}
+	}
+
+	return sb.String(), nil
+}
+
+// RenderHTML walks the tree's visible nodes and emits a nested <ul><li>
+// document, honoring expansion/visibility state exactly like renderTree.
+// Each <li> carries data-id and data-depth attributes, plus a CSS class
+// from ClassProvider.Class when the provider implements it, so the output
+// can be styled or made interactive without re-walking the tree.
+func RenderHTML[T any](ctx context.Context, tree *Tree[T]) (string, error) {
+	var sb strings.Builder
+	classOf, _ := any(tree.provider).(ClassProvider[T])
+	prevDepth := -1
+
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return sb.String(), err
+		}
+		node := info.Node
+		depth := info.Depth
+
+		switch {
+		case prevDepth == -1, depth > prevDepth:
+			sb.WriteString("<ul>")
+		case depth == prevDepth:
+			sb.WriteString("</li>")
+		default: // depth < prevDepth: pop back up to the common ancestor
+			for d := prevDepth; d > depth; d-- {
+				sb.WriteString("</li></ul>")
+			}
+			sb.WriteString("</li>")
+		}
+
+		class := ""
+		if classOf != nil {
+			class = classOf.Class(node)
+		}
+
+		sb.WriteString("<li")
+		if class != "" {
+			fmt.Fprintf(&sb, ` class="%s"`, html.EscapeString(class))
+		}
+		fmt.Fprintf(&sb, ` data-id="%s" data-depth="%d">`,
+			html.EscapeString(fmt.Sprint(node.ID())), depth)
+		if icon := strings.TrimRight(tree.provider.Icon(node), " "); icon != "" {
+			fmt.Fprintf(&sb, `<span class="treeview-icon">%s</span>`, html.EscapeString(icon))
+		}
+		sb.WriteString(html.EscapeString(tree.provider.Format(node)))
+
+		prevDepth = depth
+
+		if err := ctx.Err(); err != nil {
+			return sb.String(), err
+		}
+	}
+
+	if prevDepth >= 0 {
+		sb.WriteString("</li>")
+		for d := prevDepth; d > 0; d-- {
+			sb.WriteString("</ul></li>")
+		}
+		sb.WriteString("</ul>")
+	}
+
+	return sb.String(), nil
+}
+
+// jsonNode is the document shape RenderJSON serializes a tree into.
+type jsonNode struct {
+	ID       string      `json:"id"`
+	Label    string      `json:"label"`
+	Children []*jsonNode `json:"children,omitempty"`
+}
+
+// RenderJSON walks the tree's visible nodes and serializes them into a
+// hierarchical {id,label,children:[...]} document, honoring the same
+// expansion/visibility state as renderTree. Returns the top-level roots,
+// since a tree may have more than one.
+func RenderJSON[T any](ctx context.Context, tree *Tree[T]) ([]byte, error) {
+	var roots []*jsonNode
+
+	// ancestors[d] is the most recently emitted node at depth d, used to
+	// attach each new node to its parent's Children slice. This mirrors the
+	// ancestorIsLastChild bookkeeping renderTree uses for prefixes.
+	var ancestors []*jsonNode
+
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return nil, err
+		}
+		node := info.Node
+		depth := info.Depth
+
+		n := &jsonNode{
+			ID:    fmt.Sprint(node.ID()),
+			Label: tree.provider.Format(node),
+		}
+
+		if depth == 0 {
+			roots = append(roots, n)
+		} else {
+			ancestors[depth-1].Children = append(ancestors[depth-1].Children, n)
+		}
+
+		if depth >= len(ancestors) {
+			ancestors = append(ancestors, n)
+		} else {
+			ancestors[depth] = n
+			ancestors = ancestors[:depth+1]
+		}
+
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(roots)
+}
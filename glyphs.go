@@ -0,0 +1,78 @@
+package treeview
+
+// GlyphSet defines the strings used to draw the tree's branch guides. All
+// four fields must render to the same width (see Width) so that sibling and
+// ancestor guides stay aligned regardless of which one is used on a given
+// line.
+type GlyphSet struct {
+	// Vertical continues an ancestor's guide line down past a node that has
+	// more siblings below it, e.g. "│   ".
+	Vertical string
+	// Space replaces an ancestor's guide line once that ancestor was the
+	// last of its siblings, e.g. "    ".
+	Space string
+	// Branch marks a node that has more siblings below it, e.g. "├── ".
+	Branch string
+	// LastBranch marks the last child among its siblings, e.g. "└── ".
+	LastBranch string
+	// Width is the rune width shared by Vertical, Space, Branch, and
+	// LastBranch. renderTreeTable uses it to compute a node's tree-prefix
+	// width directly from its depth (depth+1 cells of Width each) instead of
+	// re-walking ancestor state, so table columns line up regardless of tree
+	// depth. A custom GlyphSet must set this to the true rendered width of
+	// its four strings, or columns will misalign; see glyphsOrDefault for
+	// what happens if it's left at zero.
+	Width int
+}
+
+// ASCIIGlyphs draws tree branches using only 7-bit ASCII characters, for
+// terminals, logs, or CI output that can't render Unicode box-drawing
+// characters.
+var ASCIIGlyphs = GlyphSet{
+	Vertical:   "|   ",
+	Space:      "    ",
+	Branch:     "+-- ",
+	LastBranch: "\\-- ",
+	Width:      4,
+}
+
+// UnicodeGlyphs is the classic box-drawing glyph set and the default used
+// throughout this package.
+var UnicodeGlyphs = GlyphSet{
+	Vertical:   "│   ",
+	Space:      "    ",
+	Branch:     "├── ",
+	LastBranch: "└── ",
+	Width:      4,
+}
+
+// RoundedGlyphs is UnicodeGlyphs with a rounded corner for the last branch.
+var RoundedGlyphs = GlyphSet{
+	Vertical:   "│   ",
+	Space:      "    ",
+	Branch:     "├── ",
+	LastBranch: "╰── ",
+	Width:      4,
+}
+
+// HeavyGlyphs draws branches with heavy/bold box-drawing characters.
+var HeavyGlyphs = GlyphSet{
+	Vertical:   "┃   ",
+	Space:      "    ",
+	Branch:     "┣━━ ",
+	LastBranch: "┗━━ ",
+	Width:      4,
+}
+
+// glyphsOrDefault returns glyphs as-is, unless its Width is zero, in which
+// case it returns UnicodeGlyphs so existing callers keep their current
+// output. Width==0 is treated as "never configured" (the zero value of an
+// unset Tree.glyphs field), so a custom GlyphSet that deliberately leaves
+// Width unset is silently replaced by UnicodeGlyphs rather than used as-is;
+// always set Width on a custom GlyphSet to opt out of this fallback.
+func glyphsOrDefault(glyphs GlyphSet) GlyphSet {
+	if glyphs.Width == 0 {
+		return UnicodeGlyphs
+	}
+	return glyphs
+}
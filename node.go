@@ -0,0 +1,32 @@
+package treeview
+
+// Node is a single element in a Tree. It holds the caller-supplied value
+// alongside the bookkeeping treeview needs to track hierarchy and
+// expansion state.
+type Node[T any] struct {
+	id       string
+	value    T
+	children []*Node[T]
+	expanded bool
+}
+
+// NewNode creates a Node identified by id, wrapping value, with the given
+// children. Nodes start expanded.
+func NewNode[T any](id string, value T, children ...*Node[T]) *Node[T] {
+	return &Node[T]{id: id, value: value, children: children, expanded: true}
+}
+
+// ID returns the node's stable identifier.
+func (n *Node[T]) ID() string { return n.id }
+
+// Value returns the caller-supplied value this node wraps.
+func (n *Node[T]) Value() T { return n.value }
+
+// Children returns the node's direct children.
+func (n *Node[T]) Children() []*Node[T] { return n.children }
+
+// Expanded reports whether the node's children should be visible.
+func (n *Node[T]) Expanded() bool { return n.expanded }
+
+// SetExpanded sets whether the node's children should be visible.
+func (n *Node[T]) SetExpanded(expanded bool) { n.expanded = expanded }
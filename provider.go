@@ -0,0 +1,22 @@
+package treeview
+
+import "github.com/charmbracelet/lipgloss"
+
+// NodeProvider adapts a caller's data into the icon, label, and style
+// treeview needs to render a Node. Implementations may optionally satisfy
+// GuideStyler, ColumnProvider, or ClassProvider to opt into per-guide
+// styling, table columns, or HTML classes respectively. A provider that
+// doesn't implement GuideStyler gets unstyled, neutral tree guides (the
+// vertical lines and ├──/└── glyphs render without Style's color or
+// background), rather than inheriting the node's own Style the way guides
+// used to before GuideStyler existed.
+type NodeProvider[T any] interface {
+	// Icon returns the (optionally empty) glyph shown before a node's label.
+	Icon(node *Node[T]) string
+	// Format returns the human-readable label for a node. It may contain
+	// "\n" to render a multi-line label; renderNode splits on it and keeps
+	// continuation lines aligned under the tree guides.
+	Format(node *Node[T]) string
+	// Style returns the style applied to a node's icon and label.
+	Style(node *Node[T], isFocused bool) lipgloss.Style
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
 )
 
@@ -13,10 +14,13 @@ var sbPool = sync.Pool{New: func() any { return new(strings.Builder) }}
 
 // renderNode implements the NodeRenderer interface. It asks the NodeProvider
 // for icon, label, and style information, then returns the final string for a
-// single line including tree-branch glyphs.
+// single node, including tree-branch glyphs. When the provider's Format
+// returns text containing "\n", the result spans multiple lines: the first
+// line uses prefix, and every continuation line uses continuationPrefix so
+// the ancestor guides keep lining up under the branch glyphs.
 //
 // The function is fast and does not allocate beyond what the provider allocates.
-func renderNode[T any](provider NodeProvider[T], node *Node[T], prefix string, isFocused bool) (string, error) {
+func renderNode[T any](provider NodeProvider[T], node *Node[T], prefix, continuationPrefix string, isFocused bool) (string, error) {
 	// Get the icon from the provider and ensure consistent width
 	// This keeps the tree aligned even with different icon widths
 	icon := NormalizeIconWidth(provider.Icon(node))
@@ -27,9 +31,49 @@ func renderNode[T any](provider NodeProvider[T], node *Node[T], prefix string, i
 	// Get the appropriate style based on focus state
 	style := provider.Style(node, isFocused)
 
-	// Combine all parts and apply the style
-	// Result: "│   └── 📁 folder-name/" (styled)
-	return style.Render(prefix + icon + displayText), nil
+	// Render the branch guides (│, ├──, └──) independently of the node
+	// body's style, so a focused/selected/error style applied to the body
+	// doesn't bleed a background color across the guide column. Providers
+	// that don't implement GuideStyler get an unstyled guide from
+	// guideStyleFor.
+	guideStyle := guideStyleFor(provider, node, isFocused)
+
+	// Fast path: single-line label, no continuation lines needed.
+	lines := strings.Split(displayText, "\n")
+	if len(lines) == 1 {
+		return guideStyle.Render(prefix) + style.Render(icon+displayText), nil
+	}
+
+	// Multi-line label: indent continuation lines past the icon column so
+	// they line up under the first line's text, and carry the ancestor
+	// guides instead of the branch glyph.
+	iconIndent := strings.Repeat(" ", runewidth.StringWidth(icon))
+
+	var sb strings.Builder
+	sb.WriteString(guideStyle.Render(prefix) + style.Render(icon+lines[0]))
+	for _, line := range lines[1:] {
+		sb.WriteByte('\n')
+		sb.WriteString(guideStyle.Render(continuationPrefix) + style.Render(iconIndent+line))
+	}
+	return sb.String(), nil
+}
+
+// GuideStyler is an optional interface a NodeProvider can implement to style
+// the tree branch guides (the vertical lines and ├──/└── glyphs) separately
+// from the node body returned by Style. This mirrors the separation exa's
+// tree output makes between the "tree part" and the "file part" of each row.
+// Providers that don't implement it get an unstyled, neutral guide.
+type GuideStyler[T any] interface {
+	GuideStyle(node *Node[T], isFocused bool) lipgloss.Style
+}
+
+// guideStyleFor returns provider's guide style if it implements GuideStyler,
+// or a new, unstyled lipgloss.Style otherwise.
+func guideStyleFor[T any](provider NodeProvider[T], node *Node[T], isFocused bool) lipgloss.Style {
+	if gs, ok := any(provider).(GuideStyler[T]); ok {
+		return gs.GuideStyle(node, isFocused)
+	}
+	return lipgloss.NewStyle()
 }
 
 // renderTree walks the tree, turns every visible node into a line.
@@ -56,6 +100,7 @@ func renderTree[T any](ctx context.Context, tree *Tree[T]) (string, int, error)
 	//
 	// The slice index corresponds to the depth level
 	var ancestorIsLastChild []bool
+	glyphs := glyphsOrDefault(tree.glyphs)
 
 	for info, err := range tree.AllVisible(ctx) {
 		if err != nil {
@@ -76,20 +121,23 @@ func renderTree[T any](ctx context.Context, tree *Tree[T]) (string, int, error)
 
 		// Build the tree branch prefix based on ancestor positions
 		// Root nodes (depth 0) get no prefix at all
-		var prefix string
+		var prefix, continuationPrefix string
 		if depth > 0 {
-			prefix = buildPrefix(ancestorIsLastChild[:depth], isLast)
+			prefix = buildPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
+			continuationPrefix = buildContinuationPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
 		}
 
 		// Check if this node should be highlighted as focused
 		isFocused := tree.IsFocused(node.ID())
 		if isFocused && focusedLineIndex == -1 {
-			// Set focused line index to the first focused node for viewport positioning
+			// Set focused line index to the first visual line of the focused
+			// node for viewport positioning, even if its label spans several
+			// physical lines.
 			focusedLineIndex = lineIdx
 		}
 
 		// Render the actual node content
-		line, err := renderNode(tree.provider, node, prefix, isFocused)
+		line, err := renderNode(tree.provider, node, prefix, continuationPrefix, isFocused)
 		if err != nil {
 			return sb.String(), focusedLineIndex, err
 		}
@@ -99,7 +147,11 @@ func renderTree[T any](ctx context.Context, tree *Tree[T]) (string, int, error)
 			sb.WriteByte('\n')
 		}
 		sb.WriteString(line)
-		lineIdx++
+
+		// A node's rendered content may itself span multiple physical lines
+		// (multi-line labels), so advance lineIdx by however many it produced
+		// rather than assuming one line per node.
+		lineIdx += strings.Count(line, "\n") + 1
 
 		// Check for context cancellation
 		if err := ctx.Err(); err != nil {
@@ -107,12 +159,31 @@ func renderTree[T any](ctx context.Context, tree *Tree[T]) (string, int, error)
 		}
 	}
 
+	// A full walk just counted every visible line: cache it so
+	// renderTreeWithViewport's next call can prefer the cheaper windowed
+	// path instead of materializing the whole tree again.
+	tree.visibleCount = lineIdx
+	tree.visibleCountValid = true
+
 	return sb.String(), focusedLineIndex, nil
 }
 
 // renderTreeWithViewport combines tree rendering with viewport scrolling.
 // It automatically positions the viewport to keep the focused line visible.
+//
+// When tree's VisibleCount hint is valid, this prefers a windowed render
+// (see renderTreeWindow) over materializing the whole tree, so redraw cost
+// stays proportional to the viewport height rather than tree size. The
+// hint starts invalid, so the first call always does a full render, which
+// populates it for the calls after it until Tree.SetExpanded invalidates it
+// again.
 func renderTreeWithViewport[T any](ctx context.Context, tree *Tree[T], vp *viewport.Model) (string, error) {
+	if vch, ok := any(tree).(visibleCountHinter); ok && vp.Height > 0 {
+		if _, hintValid := vch.VisibleCount(); hintValid {
+			return renderTreeWithViewportWindowed(ctx, tree, vp)
+		}
+	}
+
 	content, focusedLineIndex, err := renderTree(ctx, tree)
 	// Error can't impact the viewport, so we ignore it during vp setup,
 	// but we return it so callers can handle it if they want.
@@ -153,27 +224,64 @@ func renderTreeWithViewport[T any](ctx context.Context, tree *Tree[T], vp *viewp
 //	ancestorIsLastChild = [false,  true], isLast = true  → "│       └── " (complex nesting)
 //
 // This creates the complete visual tree structure including vertical lines and branch characters.
-func buildPrefix(ancestorIsLastChild []bool, isLast bool) string {
+// The examples above assume UnicodeGlyphs; passing a different glyphs argument
+// (ASCIIGlyphs, RoundedGlyphs, HeavyGlyphs, ...) substitutes its characters instead.
+func buildPrefix(ancestorIsLastChild []bool, isLast bool, glyphs GlyphSet) string {
 	var prefixBuilder strings.Builder
 
 	// Add vertical lines for ancestors
 	for _, isLastChild := range ancestorIsLastChild {
 		if isLastChild {
 			// Parent was last child
-			prefixBuilder.WriteString("    ")
+			prefixBuilder.WriteString(glyphs.Space)
 		} else {
 			// Parent has more siblings
-			prefixBuilder.WriteString("│   ")
+			prefixBuilder.WriteString(glyphs.Vertical)
 		}
 	}
 
 	// Add the final branch character
 	if isLast {
-		// Last child gets └── branch
-		prefixBuilder.WriteString("└── ")
+		// Last child gets the "last branch" glyph
+		prefixBuilder.WriteString(glyphs.LastBranch)
+	} else {
+		// Other children get the regular branch glyph
+		prefixBuilder.WriteString(glyphs.Branch)
+	}
+
+	return prefixBuilder.String()
+}
+
+// buildContinuationPrefix constructs the prefix used for the second and
+// later physical lines of a multi-line node label (see renderNode). It
+// carries the same ancestor vertical guides as buildPrefix, but replaces the
+// current node's own branch cell with "│   " if the node has following
+// siblings, or "    " if it is the last child, since a continuation line
+// does not branch off of anything.
+//
+// Examples:
+//
+//	ancestorIsLastChild = [],      isLast = false → "│   "     (node has siblings)
+//	ancestorIsLastChild = [],      isLast = true  → "    "     (node is last sibling)
+//	ancestorIsLastChild = [false], isLast = true  → "│       " (parent has siblings, node is last)
+func buildContinuationPrefix(ancestorIsLastChild []bool, isLast bool, glyphs GlyphSet) string {
+	var prefixBuilder strings.Builder
+
+	// Add vertical lines for ancestors, exactly as buildPrefix does.
+	for _, isLastChild := range ancestorIsLastChild {
+		if isLastChild {
+			prefixBuilder.WriteString(glyphs.Space)
+		} else {
+			prefixBuilder.WriteString(glyphs.Vertical)
+		}
+	}
+
+	// In place of this node's own branch glyph, continue its vertical line
+	// if it has more siblings below it, or leave blank space if it doesn't.
+	if isLast {
+		prefixBuilder.WriteString(glyphs.Space)
 	} else {
-		// Other children get ├── branch
-		prefixBuilder.WriteString("├── ")
+		prefixBuilder.WriteString(glyphs.Vertical)
 	}
 
 	return prefixBuilder.String()
@@ -0,0 +1,274 @@
+package treeview
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+// ColumnAlign controls how a column's cells are padded relative to its
+// computed width.
+type ColumnAlign int
+
+const (
+	// AlignLeft pads cells on the right so text starts flush left.
+	AlignLeft ColumnAlign = iota
+	// AlignRight pads cells on the left so text ends flush right.
+	AlignRight
+)
+
+// ColumnSpec describes one extra column in a details/table render: its
+// header text and how its cells should be aligned once every cell's width
+// is known.
+type ColumnSpec struct {
+	Header string
+	Align  ColumnAlign
+}
+
+// ColumnProvider is implemented by a NodeProvider that wants to render extra
+// aligned columns after the tree glyph/icon/label, similar to `exa --tree
+// --long`. Columns returns the cell values for node, in the same order as
+// ColumnSpecs.
+type ColumnProvider[T any] interface {
+	NodeProvider[T]
+
+	// Columns returns the cell text for node, one entry per ColumnSpec
+	// returned by ColumnSpecs. A short slice is padded with empty cells.
+	Columns(node *Node[T]) []string
+
+	// ColumnSpecs describes the extra columns, in display order.
+	ColumnSpecs() []ColumnSpec
+}
+
+// nameCellWidth returns the rune width of node's tree-prefix/icon/label cell
+// as renderTreeTable will emit it: the prefix's width is derived from depth
+// and glyphs.Width rather than re-walking ancestor state, and only the first
+// physical line of a multi-line label counts, since that's the only line
+// extra columns ever sit next to.
+func nameCellWidth[T any](provider NodeProvider[T], node *Node[T], depth int, glyphs GlyphSet) int {
+	prefixWidth := 0
+	if depth > 0 {
+		prefixWidth = (depth + 1) * glyphs.Width
+	}
+
+	icon := NormalizeIconWidth(provider.Icon(node))
+	label := provider.Format(node)
+	if i := strings.IndexByte(label, '\n'); i >= 0 {
+		label = label[:i]
+	}
+
+	return prefixWidth + runewidth.StringWidth(icon) + runewidth.StringWidth(label)
+}
+
+// renderTreeTable walks the tree exactly like renderTree, but appends a row
+// of aligned extra columns after the tree-prefix/icon/label portion of each
+// line, preceded by a header row of each ColumnSpec's Header. It performs
+// two passes over the visible nodes: the first measures the widest
+// tree-prefix/icon/label cell (nameCellWidth) and the widest cell per column
+// with runewidth.StringWidth, so columns start at the same screen column on
+// every row regardless of tree depth; the second emits the header and the
+// padded rows. For a node whose label spans multiple physical lines, the
+// columns are attached to the first line only, so they sit next to the
+// node's label rather than its last continuation line.
+func renderTreeTable[T any](ctx context.Context, tree *Tree[T], provider ColumnProvider[T]) (string, int, error) {
+	specs := provider.ColumnSpecs()
+	widths := make([]int, len(specs))
+	for i, spec := range specs {
+		widths[i] = runewidth.StringWidth(spec.Header)
+	}
+
+	glyphs := glyphsOrDefault(tree.glyphs)
+	nameWidth := 0
+
+	// First pass: measure the name cell and the widest cell in each column.
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return "", 0, err
+		}
+		if w := nameCellWidth[T](provider, info.Node, info.Depth, glyphs); w > nameWidth {
+			nameWidth = w
+		}
+		cells := provider.Columns(info.Node)
+		for i := range specs {
+			if i >= len(cells) {
+				continue
+			}
+			if w := runewidth.StringWidth(cells[i]); w > widths[i] {
+				widths[i] = w
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return "", 0, err
+		}
+	}
+
+	sb := sbPool.Get().(*strings.Builder)
+	defer func() {
+		sb.Reset()
+		sbPool.Put(sb)
+	}()
+
+	lineIdx := 0
+	focusedLineIndex := -1
+	var ancestorIsLastChild []bool
+
+	if len(specs) > 0 {
+		sb.WriteString(strings.Repeat(" ", nameWidth))
+		for i, spec := range specs {
+			sb.WriteString("  ")
+			sb.WriteString(padCell(spec.Header, widths[i], spec.Align))
+		}
+		lineIdx++
+	}
+
+	// Second pass: emit each row with its tree prefix, padded to nameWidth,
+	// followed by the padded columns.
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return sb.String(), focusedLineIndex, err
+		}
+		node := info.Node
+		depth := info.Depth
+		isLast := info.IsLast
+
+		if depth >= len(ancestorIsLastChild) {
+			ancestorIsLastChild = append(ancestorIsLastChild, isLast)
+		} else {
+			ancestorIsLastChild[depth] = isLast
+			ancestorIsLastChild = ancestorIsLastChild[:depth+1]
+		}
+
+		var prefix, continuationPrefix string
+		if depth > 0 {
+			prefix = buildPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
+			continuationPrefix = buildContinuationPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
+		}
+
+		isFocused := tree.IsFocused(node.ID())
+		if isFocused && focusedLineIndex == -1 {
+			focusedLineIndex = lineIdx
+		}
+
+		line, err := renderNode(provider, node, prefix, continuationPrefix, isFocused)
+		if err != nil {
+			return sb.String(), focusedLineIndex, err
+		}
+
+		// Attach the padded columns to the first physical line only, so a
+		// multi-line label's columns sit next to its label, not its last
+		// continuation line.
+		firstLine, rest, multiline := strings.Cut(line, "\n")
+		if pad := nameWidth - nameCellWidth[T](provider, node, depth, glyphs); pad > 0 {
+			firstLine += strings.Repeat(" ", pad)
+		}
+		firstLine += renderColumns(provider, node, specs, widths, isFocused)
+
+		if lineIdx > 0 || len(specs) > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(firstLine)
+		if multiline {
+			sb.WriteByte('\n')
+			sb.WriteString(rest)
+		}
+		lineIdx += strings.Count(line, "\n") + 1
+
+		if err := ctx.Err(); err != nil {
+			return sb.String(), focusedLineIndex, err
+		}
+	}
+
+	return sb.String(), focusedLineIndex, nil
+}
+
+// RenderTable renders tree as a details/table view: each visible node's
+// tree-prefix/icon/label is followed by the aligned extra columns provider
+// describes via ColumnSpecs. It's the exported entry point for the table
+// mode implemented by renderTreeTable, for callers not driving a
+// bubbles/viewport.
+func RenderTable[T any](ctx context.Context, tree *Tree[T], provider ColumnProvider[T]) (string, error) {
+	content, _, err := renderTreeTable(ctx, tree, provider)
+	return content, err
+}
+
+// RenderTableWithViewport is RenderTable combined with viewport scrolling,
+// auto-scrolling to keep the focused node visible exactly like
+// renderTreeWithViewport does for the plain tree view.
+func RenderTableWithViewport[T any](ctx context.Context, tree *Tree[T], provider ColumnProvider[T], vp *viewport.Model) (string, error) {
+	return renderTreeTableWithViewport(ctx, tree, provider, vp)
+}
+
+// renderTreeTableWithViewport combines table rendering with viewport
+// scrolling, mirroring renderTreeWithViewport's auto-scroll-to-focus
+// behavior.
+func renderTreeTableWithViewport[T any](ctx context.Context, tree *Tree[T], provider ColumnProvider[T], vp *viewport.Model) (string, error) {
+	content, focusedLineIndex, err := renderTreeTable(ctx, tree, provider)
+	vp.SetContent(content)
+
+	if focusedLineIndex >= 0 && vp.Height > 0 {
+		if focusedLineIndex < vp.YOffset {
+			vp.YOffset = focusedLineIndex
+		} else if focusedLineIndex >= vp.YOffset+vp.Height {
+			vp.YOffset = focusedLineIndex - vp.Height + 1
+			vp.YOffset = max(vp.YOffset, 0)
+		}
+	}
+
+	return vp.View(), err
+}
+
+// renderColumns builds the padded, styled "  col1  col2  ..." suffix for a
+// single row, given the column widths computed by renderTreeTable's first
+// pass.
+func renderColumns[T any](provider ColumnProvider[T], node *Node[T], specs []ColumnSpec, widths []int, isFocused bool) string {
+	if len(specs) == 0 {
+		return ""
+	}
+
+	cells := provider.Columns(node)
+
+	var sb strings.Builder
+	for i, spec := range specs {
+		var cell string
+		if i < len(cells) {
+			cell = cells[i]
+		}
+
+		padded := padCell(cell, widths[i], spec.Align)
+
+		sb.WriteString("  ")
+		if cp, ok := any(provider).(ColumnStyler[T]); ok {
+			sb.WriteString(cp.ColumnStyle(node, i, isFocused).Render(padded))
+		} else {
+			sb.WriteString(padded)
+		}
+	}
+
+	return sb.String()
+}
+
+// padCell pads cell with spaces so its rune width equals width, honoring
+// align. Cells already at or beyond width are returned unchanged.
+func padCell(cell string, width int, align ColumnAlign) string {
+	pad := width - runewidth.StringWidth(cell)
+	if pad <= 0 {
+		return cell
+	}
+
+	spaces := strings.Repeat(" ", pad)
+	if align == AlignRight {
+		return spaces + cell
+	}
+	return cell + spaces
+}
+
+// ColumnStyler is an optional interface a ColumnProvider can implement to
+// style individual columns independently of the node's overall Style, e.g.
+// to dim a size column or color a status column. When not implemented,
+// columns render unstyled.
+type ColumnStyler[T any] interface {
+	ColumnStyle(node *Node[T], col int, isFocused bool) lipgloss.Style
+}
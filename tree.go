@@ -0,0 +1,133 @@
+package treeview
+
+import (
+	"context"
+	"iter"
+)
+
+// VisibleInfo describes one node yielded by Tree.AllVisible: its position
+// relative to its siblings and ancestors, which renderers use to draw tree
+// guides.
+type VisibleInfo[T any] struct {
+	Node   *Node[T]
+	Depth  int
+	IsLast bool
+}
+
+// Tree renders a forest of Node values through a NodeProvider.
+type Tree[T any] struct {
+	roots    []*Node[T]
+	provider NodeProvider[T]
+	glyphs   GlyphSet
+	focused  string
+
+	// visibleCount and visibleCountValid back VisibleCount's O(1) hint. The
+	// cache is populated by any walk of every visible node (renderTree,
+	// renderTreeWindow) and invalidated by Tree.SetExpanded. Calling
+	// Node.SetExpanded directly does not invalidate it, so the cache can then
+	// be valid but stale.
+	visibleCount      int
+	visibleCountValid bool
+}
+
+// TreeOption configures a Tree at construction time.
+type TreeOption[T any] func(*Tree[T])
+
+// WithGlyphs sets the GlyphSet a Tree uses to draw its branch guides.
+// Without this option, a Tree draws guides with UnicodeGlyphs; pass
+// ASCIIGlyphs, RoundedGlyphs, or HeavyGlyphs for other terminals, or a
+// custom GlyphSet.
+func WithGlyphs[T any](glyphs GlyphSet) TreeOption[T] {
+	return func(t *Tree[T]) { t.glyphs = glyphs }
+}
+
+// NewTree builds a Tree over roots, rendered through provider.
+func NewTree[T any](roots []*Node[T], provider NodeProvider[T], opts ...TreeOption[T]) *Tree[T] {
+	t := &Tree[T]{roots: roots, provider: provider}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// SetGlyphs changes the GlyphSet this tree uses to draw its branch guides,
+// for callers that want to switch it after construction (e.g. a user
+// toggling ASCII output at runtime).
+func (t *Tree[T]) SetGlyphs(glyphs GlyphSet) { t.glyphs = glyphs }
+
+// IsFocused reports whether the node identified by id is the tree's
+// currently focused node.
+func (t *Tree[T]) IsFocused(id string) bool { return t.focused == id }
+
+// SetFocused sets the tree's focused node by ID.
+func (t *Tree[T]) SetFocused(id string) { t.focused = id }
+
+// VisibleCount returns the tree's cached visible-line count and whether
+// that cache is currently valid. It's an O(1) hint: renderTreeWithViewport
+// uses it to decide whether a windowed render (see renderTreeWindow) is
+// safe to prefer over materializing the whole tree. The cache is populated
+// as a byproduct of a full render and invalidated by SetExpanded, so a
+// structural change costs one more full render before windowing resumes.
+func (t *Tree[T]) VisibleCount() (int, bool) {
+	return t.visibleCount, t.visibleCountValid
+}
+
+// SetExpanded sets the expansion state of the node identified by id, if
+// it's found in the tree, and reports whether it was found. This is the
+// sanctioned way to change expansion state: unlike calling Node.SetExpanded
+// directly, it invalidates the VisibleCount cache so the next render
+// recomputes it instead of trusting a now-stale count.
+func (t *Tree[T]) SetExpanded(id string, expanded bool) bool {
+	var walk func(nodes []*Node[T]) bool
+	walk = func(nodes []*Node[T]) bool {
+		for _, n := range nodes {
+			if n.ID() == id {
+				n.SetExpanded(expanded)
+				return true
+			}
+			if walk(n.Children()) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if !walk(t.roots) {
+		return false
+	}
+	t.visibleCountValid = false
+	return true
+}
+
+// AllVisible iterates every visible node in the tree in depth-first order,
+// skipping the children of any node whose Expanded is false. Iteration
+// stops early, yielding a final (zero, ctx.Err()) pair, if ctx is canceled.
+func (t *Tree[T]) AllVisible(ctx context.Context) iter.Seq2[VisibleInfo[T], error] {
+	return func(yield func(VisibleInfo[T], error) bool) {
+		var walk func(node *Node[T], depth int, isLast bool) bool
+		walk = func(node *Node[T], depth int, isLast bool) bool {
+			if err := ctx.Err(); err != nil {
+				yield(VisibleInfo[T]{}, err)
+				return false
+			}
+			if !yield(VisibleInfo[T]{Node: node, Depth: depth, IsLast: isLast}, nil) {
+				return false
+			}
+			if !node.Expanded() {
+				return true
+			}
+			children := node.Children()
+			for i, child := range children {
+				if !walk(child, depth+1, i == len(children)-1) {
+					return false
+				}
+			}
+			return true
+		}
+		for i, root := range t.roots {
+			if !walk(root, 0, i == len(t.roots)-1) {
+				return
+			}
+		}
+	}
+}
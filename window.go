@@ -0,0 +1,185 @@
+package treeview
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// windowOverscan is the number of extra lines rendered above and below the
+// requested window, so that a one-line scroll doesn't need a full re-render
+// and minor off-by-one viewport math doesn't show a blank line at the edge.
+const windowOverscan = 5
+
+// visibleCountHinter is implemented by a Tree that can report how many
+// visible lines it has without walking the whole tree (e.g. because it
+// already caches the count for its own bookkeeping). renderTreeWithViewport
+// uses this to decide whether a windowed render is worth preferring over a
+// full one.
+type visibleCountHinter interface {
+	VisibleCount() (int, bool)
+}
+
+// renderTreeWindow walks the tree like renderTree, but only formats nodes
+// whose line index falls in [yOffset-windowOverscan, yOffset+height+windowOverscan).
+// Nodes outside that range are still walked, and their true line span is
+// still counted (via provider.Format, without the cost of icon
+// normalization or style rendering), so ancestorIsLastChild (and so
+// prefixes) and every line index this function returns stay correct even
+// when out-of-window nodes have multi-line labels. This keeps the cost of
+// a redraw proportional to the viewport height rather than the size of the
+// tree. It returns the window's content, the line the window's content
+// starts at, the focused node's line index within the *full* tree (or -1
+// if none is visible), and the total number of visible lines. As a side
+// effect, since this walks every visible node, it refreshes the tree's
+// VisibleCount cache.
+func renderTreeWindow[T any](ctx context.Context, tree *Tree[T], yOffset, height int) (content string, startLine, focusedLineIndex, totalLines int, err error) {
+	lo := yOffset - windowOverscan
+	if lo < 0 {
+		lo = 0
+	}
+	hi := yOffset + height + windowOverscan
+
+	sb := sbPool.Get().(*strings.Builder)
+	defer func() {
+		sb.Reset()
+		sbPool.Put(sb)
+	}()
+
+	lineIdx := 0
+	linesWritten := 0
+	startLine = lo
+	focusedLineIndex = -1
+	var ancestorIsLastChild []bool
+	glyphs := glyphsOrDefault(tree.glyphs)
+
+	for info, err := range tree.AllVisible(ctx) {
+		if err != nil {
+			return "", lo, 0, 0, err
+		}
+		node := info.Node
+		depth := info.Depth
+		isLast := info.IsLast
+
+		// Keep ancestor bookkeeping up to date for every node, in or out of
+		// the window, so prefixes inside the window stay correct.
+		if depth >= len(ancestorIsLastChild) {
+			ancestorIsLastChild = append(ancestorIsLastChild, isLast)
+		} else {
+			ancestorIsLastChild[depth] = isLast
+			ancestorIsLastChild = ancestorIsLastChild[:depth+1]
+		}
+
+		isFocused := tree.IsFocused(node.ID())
+		if isFocused && focusedLineIndex == -1 {
+			focusedLineIndex = lineIdx
+		}
+
+		if lineIdx < lo || lineIdx >= hi {
+			// Outside the window: skip icon normalization and style
+			// rendering (the expensive part this function exists to
+			// avoid), but still count the node's true line span so
+			// lineIdx doesn't drift when its label spans multiple lines.
+			lineIdx += strings.Count(tree.provider.Format(node), "\n") + 1
+			continue
+		}
+
+		var prefix, continuationPrefix string
+		if depth > 0 {
+			prefix = buildPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
+			continuationPrefix = buildContinuationPrefix(ancestorIsLastChild[:depth], isLast, glyphs)
+		}
+
+		line, renderErr := renderNode(tree.provider, node, prefix, continuationPrefix, isFocused)
+		if renderErr != nil {
+			return sb.String(), startLine, focusedLineIndex, lineIdx, renderErr
+		}
+
+		if linesWritten > 0 {
+			sb.WriteByte('\n')
+		} else {
+			// A multi-line node that starts before lo is skipped above, so
+			// the content can start after lo.
+			startLine = lineIdx
+		}
+		sb.WriteString(line)
+		lineSpan := strings.Count(line, "\n") + 1
+		linesWritten += lineSpan
+		lineIdx += lineSpan
+
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return sb.String(), startLine, focusedLineIndex, lineIdx, ctxErr
+		}
+	}
+
+	tree.visibleCount = lineIdx
+	tree.visibleCountValid = true
+
+	return sb.String(), startLine, focusedLineIndex, lineIdx, nil
+}
+
+// RenderWindow renders only the nodes visible in [yOffset, yOffset+height)
+// of the tree (plus a small overscan margin), without materializing the
+// full tree's content. It's meant for callers driving their own scroll UI
+// over very large trees, where rendering every visible node per keystroke
+// would be prohibitively expensive. It returns the window's content, the
+// line the content starts at (which may be a few lines above yOffset
+// because of overscan — use it, not yOffset, to place the content), the
+// globally focused node's line index (or -1), and the total number of
+// visible lines.
+func RenderWindow[T any](ctx context.Context, tree *Tree[T], yOffset, height int) (content string, startLine, focusedLineIndex, totalLines int, err error) {
+	return renderTreeWindow(ctx, tree, yOffset, height)
+}
+
+// sliceLines returns the lines of content in [skip, skip+take), dropping
+// anything outside that range. It's used to trim renderTreeWindow's
+// overscanned output down to exactly what a viewport of height `take`
+// should display.
+func sliceLines(content string, skip, take int) string {
+	lines := strings.Split(content, "\n")
+	if skip < 0 {
+		skip = 0
+	}
+	if skip > len(lines) {
+		skip = len(lines)
+	}
+	lines = lines[skip:]
+
+	if take >= 0 && take < len(lines) {
+		lines = lines[:take]
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// renderTreeWithViewportWindowed is the windowed counterpart of
+// renderTreeWithViewport's body: it renders just enough of the tree around
+// vp.YOffset to find the focused line, adjusts YOffset the same way the
+// full render does, and re-renders the window if YOffset moved. Unlike
+// renderTreeWindow/RenderWindow, it trims the overscan and clamps to
+// vp.Height before returning, since it hands back a ready-to-display frame,
+// exactly as renderTreeWithViewport's vp.View() does.
+func renderTreeWithViewportWindowed[T any](ctx context.Context, tree *Tree[T], vp *viewport.Model) (string, error) {
+	content, startLine, focusedLineIndex, _, err := renderTreeWindow(ctx, tree, vp.YOffset, vp.Height)
+	if err != nil {
+		return sliceLines(content, vp.YOffset-startLine, vp.Height), err
+	}
+
+	if focusedLineIndex >= 0 {
+		if focusedLineIndex < vp.YOffset {
+			vp.YOffset = focusedLineIndex
+		} else if focusedLineIndex >= vp.YOffset+vp.Height {
+			vp.YOffset = max(focusedLineIndex-vp.Height+1, 0)
+		}
+	}
+
+	// vp.YOffset may have just moved outside the window we rendered around
+	// the old offset; re-render around the new one so the frame we slice
+	// below actually covers it.
+	if vp.YOffset < startLine || vp.YOffset+vp.Height > startLine+strings.Count(content, "\n")+1 {
+		content, startLine, _, _, err = renderTreeWindow(ctx, tree, vp.YOffset, vp.Height)
+	}
+
+	return sliceLines(content, vp.YOffset-startLine, vp.Height), err
+}